@@ -5,136 +5,385 @@ package cache
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	lru "github.com/hashicorp/golang-lru"
 	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/Jeanga7/go-cache-demo/pkg/store"
+	lrustore "github.com/Jeanga7/go-cache-demo/pkg/store/lru"
+	redisstore "github.com/Jeanga7/go-cache-demo/pkg/store/redis"
 )
 
 // ErrNotFound is returned when a requested item is not found in any cache layer.
-var ErrNotFound = errors.New("item not found in cache")
-
-// cacheEntry is an internal struct representing a single item in the in-memory cache.
-type cacheEntry struct {
-	value     []byte
-	expiresAt time.Time
-}
+var ErrNotFound = store.ErrNotFound
 
 // Options holds the configuration for creating a new Cache instance.
 // It allows for customization of connection details, cache sizes, and expiration policies.
 type Options struct {
-	// RedisAddr is the address of the Redis server (e.g., "localhost:6379").
-	// This is ignored if RedisClient is provided.
+	// RedisAddr is the address of a single Redis node (e.g., "localhost:6379").
+	// This is ignored if RedisClient, SentinelAddrs, ClusterAddrs, or Store is
+	// provided.
 	RedisAddr string
 	// DefaultTTL is the default time-to-live for cache entries.
 	DefaultTTL time.Duration
 	// MaxMemEntries is the maximum number of entries to keep in the in-memory LRU cache.
+	// This is ignored if Store is provided.
 	MaxMemEntries int
-	// RedisClient allows providing an existing Redis client. If nil, a new client is created.
-	RedisClient *redis.Client
+	// RedisClient allows providing an existing Redis client, of any topology
+	// (single node, Sentinel failover, or Cluster). If nil, a new client is
+	// created from RedisAddr, SentinelAddrs, or ClusterAddrs. This is ignored
+	// if Store is provided.
+	RedisClient redis.UniversalClient
+	// SentinelAddrs, if set together with MasterName, points New at a Redis
+	// Sentinel-monitored failover group instead of a single node.
+	SentinelAddrs []string
+	// MasterName is the name of the master monitored by SentinelAddrs.
+	MasterName string
+	// ClusterAddrs, if set, points New at a Redis Cluster instead of a
+	// single node. Takes precedence over SentinelAddrs/MasterName.
+	ClusterAddrs []string
+	// Password authenticates to the Redis topology built from RedisAddr,
+	// SentinelAddrs, or ClusterAddrs. Ignored if RedisClient or Store is
+	// provided.
+	Password string
+	// RouteByLatency routes cluster/sentinel read-only commands to the
+	// replica with the lowest latency. See redis.ClusterOptions /
+	// redis.FailoverOptions.
+	RouteByLatency bool
+	// RouteRandomly routes cluster/sentinel read-only commands to a random
+	// replica. See redis.ClusterOptions / redis.FailoverOptions.
+	RouteRandomly bool
+	// TLSConfig, if set, is used for the connection(s) to the Redis
+	// topology built from RedisAddr, SentinelAddrs, or ClusterAddrs.
+	TLSConfig *tls.Config
+	// NegativeTTL is how long a miss reported by a GetOrLoad loader (via
+	// ErrNotFound) is remembered before the loader is invoked again for the
+	// same key. If zero, DefaultTTL is used.
+	NegativeTTL time.Duration
+	// Store overrides the default {lru, redis} store.MultiStore with a
+	// caller-built store.Store, e.g. store.NewMultiStore(ttl, ristrettoStore,
+	// memcachedStore, redisStore). When set, RedisAddr, RedisClient and
+	// MaxMemEntries are ignored. Since CacheCraft cannot see inside a
+	// caller-built store, every Get against it is instrumented as a single
+	// "mem" layer (MemHits/MemMisses, mem_hit/mem_miss events) rather than
+	// split by sub-layer; RedisHits/RedisMisses stay zero.
+	Store store.Store
+	// UpdatePolicy controls how Set/Purge are propagated to other instances
+	// once EnableInvalidationBus is used. Defaults to PolicyLocalOnly, which
+	// does not publish anything.
+	UpdatePolicy UpdatePolicy
+	// MetricsCollector, if set, receives counts and latencies for every
+	// instrumented cache event (see cachemetrics/prometheus for a
+	// Prometheus-backed implementation). See Store's doc comment for how
+	// events are labeled when a caller-supplied Store is used instead of
+	// the default {lru, redis} layers.
+	MetricsCollector MetricsCollector
+	// OnEvent, if set, is called synchronously after every instrumented
+	// cache event. Use it to plug in OpenTelemetry, statsd, or custom
+	// logging without depending on MetricsCollector/Prometheus.
+	OnEvent func(Event)
 }
 
 // Cache is the main cache controller. It orchestrates the flow of data
-// between the in-memory LRU cache and the Redis cache.
+// through an ordered chain of store.Store backends, by default an in-memory
+// LRU cache backed by Redis.
 type Cache struct {
-	memCache    *lru.Cache
-	redisClient *redis.Client
+	store       store.Store
 	defaultTTL  time.Duration
+	negativeTTL time.Duration
 	ctx         context.Context
+	loaderGroup singleflight.Group
+
+	// instanceID distinguishes this Cache from others sharing the same
+	// invalidation bus, so a node never evicts its own fresh writes.
+	instanceID string
+	// redisClient is used only for the invalidation bus (publish/subscribe)
+	// and tag indexing; all data access goes through store. It is nil unless
+	// a redis.UniversalClient is available, either because the default
+	// {lru, redis} store was built or because the caller passed both Store
+	// and RedisClient. It may be backed by a single node, a Sentinel
+	// failover group, or a Redis Cluster.
+	redisClient         redis.UniversalClient
+	updatePolicy        UpdatePolicy
+	invalidationChannel string
+	pubsubCancel        context.CancelFunc
+
+	// tagMu guards tagIndex and keyTags, the local tag <-> keys indexes used
+	// by InvalidateTags and Purge. Redis keeps its own copy of tagIndex in
+	// "tag:{name}" sets.
+	tagMu    sync.Mutex
+	tagIndex map[string]map[string]struct{}
+	// keyTags is the reverse of tagIndex (key -> tags it carries), so Purge
+	// and InvalidateTags can remove a key's membership from every tag it was
+	// ever indexed under, not just the tags an InvalidateTags call named.
+	keyTags map[string][]string
+
+	counters cacheCounters
+	metrics  MetricsCollector
+	onEvent  func(Event)
 }
 
-// Stats contains statistics about the cache's performance.
+// Stats contains statistics about the cache's performance, atomically read
+// from the counters populated by Get/Set/Purge/GetOrLoad.
 type Stats struct {
-	MemHits   int
-	MemMisses int
-	MemEvicts int
-	MemLen    int
-	MemKeys   []interface{}
+	MemHits           int
+	MemMisses         int
+	MemEvicts         int
+	MemLen            int
+	MemKeys           []interface{}
+	RedisHits         int
+	RedisMisses       int
+	RedisErrors       int
+	LoaderInvocations int
 }
 
 // New initializes a new multi-layer Cache with the given options.
 // It returns an error if the configuration is invalid or if the connection to Redis fails.
 func New(opts Options) (*Cache, error) {
-	memCache, err := lru.New(opts.MaxMemEntries)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create memory cache: %w", err)
+	ctx := context.Background()
+
+	c := &Cache{
+		defaultTTL:   opts.DefaultTTL,
+		negativeTTL:  opts.NegativeTTL,
+		ctx:          ctx,
+		instanceID:   newInstanceID(),
+		updatePolicy: opts.UpdatePolicy,
+		tagIndex:     make(map[string]map[string]struct{}),
+		keyTags:      make(map[string][]string),
+		metrics:      opts.MetricsCollector,
+		onEvent:      opts.OnEvent,
 	}
 
+	s := opts.Store
 	redisClient := opts.RedisClient
-	ctx := context.Background()
+	if s == nil {
+		memStore, err := lrustore.New(opts.MaxMemEntries, lrustore.WithEvictCallback(func(key string) {
+			c.recordEvent(Event{Type: EventMemEvict, Key: key})
+		}))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create memory cache: %w", err)
+		}
 
-	if redisClient == nil {
-		redisClient = redis.NewClient(&redis.Options{
-			Addr: opts.RedisAddr,
-			DB:   0, // use default DB
-		})
+		if redisClient == nil {
+			redisClient = newRedisUniversalClient(opts)
 
-		if _, err := redisClient.Ping(ctx).Result(); err != nil {
-			return nil, fmt.Errorf("failed to connect to redis: %w", err)
+			if _, err := redisClient.Ping(ctx).Result(); err != nil {
+				return nil, fmt.Errorf("failed to connect to redis: %w", err)
+			}
 		}
+
+		s = store.NewMultiStore(opts.DefaultTTL,
+			&instrumentedStore{inner: memStore, c: c, layer: "mem"},
+			&instrumentedStore{inner: redisstore.New(redisClient), c: c, layer: "redis"},
+		)
+	} else {
+		// A caller-supplied Store is an opaque chain we cannot introspect
+		// layer by layer, so it is instrumented as a single "mem" bucket
+		// (the same bucket Stats already reports MemLen/MemKeys from for
+		// whatever store sits at c.store) rather than left uninstrumented.
+		s = &instrumentedStore{inner: s, c: c, layer: "mem"}
 	}
 
-	return &Cache{
-		memCache:    memCache,
-		redisClient: redisClient,
-		defaultTTL:  opts.DefaultTTL,
-		ctx:         ctx,
-	}, nil
+	c.store = s
+	c.redisClient = redisClient
+	return c, nil
+}
+
+// newRedisUniversalClient builds a redis.UniversalClient for the topology
+// described by opts: a Redis Cluster if ClusterAddrs is set, a Sentinel
+// failover group if MasterName and SentinelAddrs are set, or a single node
+// at RedisAddr otherwise.
+func newRedisUniversalClient(opts Options) redis.UniversalClient {
+	switch {
+	case len(opts.ClusterAddrs) > 0:
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:          opts.ClusterAddrs,
+			Password:       opts.Password,
+			RouteByLatency: opts.RouteByLatency,
+			RouteRandomly:  opts.RouteRandomly,
+			TLSConfig:      opts.TLSConfig,
+		})
+	case opts.MasterName != "" && len(opts.SentinelAddrs) > 0:
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:     opts.MasterName,
+			SentinelAddrs:  opts.SentinelAddrs,
+			Password:       opts.Password,
+			RouteByLatency: opts.RouteByLatency,
+			RouteRandomly:  opts.RouteRandomly,
+			TLSConfig:      opts.TLSConfig,
+		})
+	default:
+		return redis.NewClient(&redis.Options{
+			Addr:      opts.RedisAddr,
+			DB:        0, // use default DB
+			Password:  opts.Password,
+			TLSConfig: opts.TLSConfig,
+		})
+	}
 }
 
-// Get retrieves an item from the cache. It checks the in-memory LRU cache first,
-// then the Redis cache. If the item is not found in either, it returns ErrNotFound.
+// Get retrieves an item from the cache, walking the configured store chain
+// (by default in-memory LRU, then Redis). If the item is not found in any
+// store, it returns ErrNotFound.
 func (c *Cache) Get(id string) ([]byte, error) {
-	// 1. Check in-memory cache
-	if entryRaw, ok := c.memCache.Get(id); ok {
-		entry := entryRaw.(cacheEntry)
-		if time.Now().Before(entry.expiresAt) {
-			return entry.value, nil
-		}
-		c.memCache.Remove(id) // Expired
+	return c.store.Get(c.ctx, id)
+}
+
+// Set stores an item in every configured cache layer with the default TTL.
+// Pass WithTags to associate the key with one or more tags for later bulk
+// invalidation via InvalidateTags.
+//
+// The tag index is updated in a separate step after the value write
+// completes, not atomically with it: a crash between the two leaves a live
+// key with no tag membership, so it would survive a later InvalidateTags for
+// a tag it was meant to carry. This is a known limitation of the current
+// store.Store abstraction, which has no way to express "write the value and
+// update a side index in one transaction" for every backing store it fronts.
+func (c *Cache) Set(id string, value []byte, opts ...SetOption) {
+	var so setOptions
+	for _, opt := range opts {
+		opt(&so)
 	}
 
-	// 2. Check Redis
-	val, err := c.redisClient.Get(c.ctx, id).Bytes()
-	if err == nil {
-		// Populate in-memory cache for subsequent fast access
-		c.memCache.Add(id, cacheEntry{
-			value:     val,
-			expiresAt: time.Now().Add(c.defaultTTL),
-		})
-		return val, nil
+	_ = c.store.Set(c.ctx, id, value, c.defaultTTL)
+	if len(so.tags) > 0 {
+		c.indexTags(id, so.tags)
 	}
-	if err != redis.Nil {
-		return nil, fmt.Errorf("redis GET error: %w", err)
+	c.publishInvalidation(invalidationOpSet, id, value)
+}
+
+// Loader fetches the value for a cache key on a miss. It returns the value,
+// the TTL it should be cached for, and an error. A loader that returns
+// ErrNotFound tells GetOrLoad that the key legitimately does not exist, so
+// the miss itself is cached (negative caching) to keep repeated misses from
+// reaching the loader again.
+type Loader func(ctx context.Context) ([]byte, time.Duration, error)
+
+// negativeKey returns the cache key under which a negative-cache marker for
+// id is stored, kept separate from id's own slot so a later successful Set
+// for id can never collide with a stale miss marker.
+func negativeKey(id string) string {
+	return "neg:" + id
+}
+
+// GetOrLoad retrieves id from the cache, falling back to loader on a miss
+// and populating every cache layer with the result. Concurrent GetOrLoad
+// calls for the same id are coalesced with singleflight, so a stampede of
+// simultaneous misses results in exactly one loader invocation and one
+// round trip through the store chain; every other caller waits on and
+// receives the shared result. If loader reports ErrNotFound, the miss is
+// negative-cached for NegativeTTL so subsequent calls skip the loader until
+// it expires. A successful load is announced on the invalidation bus exactly
+// like Set, so other instances sharing it evict or refresh their local copy.
+func (c *Cache) GetOrLoad(ctx context.Context, id string, loader Loader) ([]byte, error) {
+	v, err, _ := c.loaderGroup.Do(id, func() (interface{}, error) {
+		if val, err := c.store.Get(ctx, id); err == nil {
+			return val, nil
+		} else if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+
+		if c.isNegativelyCached(ctx, id) {
+			return nil, ErrNotFound
+		}
+
+		loadStart := time.Now()
+		val, ttl, err := loader(ctx)
+		c.recordEvent(Event{Type: EventLoaderInvoked, Key: id, Latency: time.Since(loadStart)})
+		if errors.Is(err, ErrNotFound) {
+			c.setNegative(ctx, id)
+			return nil, ErrNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		if ttl <= 0 {
+			ttl = c.defaultTTL
+		}
+		if err := c.store.Set(ctx, id, val, ttl); err != nil {
+			return nil, err
+		}
+		c.publishInvalidation(invalidationOpSet, id, val)
+		return val, nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return v.([]byte), nil
+}
+
+// negativeMarker is the sentinel value stored for a negative-cached key.
+// Its content is never read back, only its presence matters.
+var negativeMarker = []byte{0}
 
-	// 3. Not found in any cache
-	return nil, ErrNotFound
+// setNegative records id as a known miss for NegativeTTL (or DefaultTTL if
+// NegativeTTL is unset). The write is tagged with skipMetricsContext so
+// instrumentedStore doesn't count this internal marker alongside id's own
+// caller-visible hit/miss stats.
+func (c *Cache) setNegative(ctx context.Context, id string) {
+	ttl := c.negativeTTL
+	if ttl <= 0 {
+		ttl = c.defaultTTL
+	}
+	_ = c.store.Set(skipMetricsContext(ctx), negativeKey(id), negativeMarker, ttl)
 }
 
-// Set stores an item in both cache layers with the default TTL.
-func (c *Cache) Set(id string, value []byte) {
-	expiresAt := time.Now().Add(c.defaultTTL)
-	// Store in memory
-	c.memCache.Add(id, cacheEntry{value: value, expiresAt: expiresAt})
-	// Store in Redis
-	c.redisClient.Set(c.ctx, id, value, c.defaultTTL)
+// isNegativelyCached reports whether id currently has a live negative-cache
+// marker. The lookup is tagged with skipMetricsContext for the same reason
+// as setNegative.
+func (c *Cache) isNegativelyCached(ctx context.Context, id string) bool {
+	_, err := c.store.Get(skipMetricsContext(ctx), negativeKey(id))
+	return err == nil
 }
 
-// Purge removes an item from both cache layers.
+// Purge removes an item from every configured cache layer, along with any
+// tag index entries it carries, so a tagged key doesn't linger as a stale
+// reference in tagIndex or Redis's "tag:{name}" sets after it's gone.
 func (c *Cache) Purge(id string) {
-	c.memCache.Remove(id)
-	c.redisClient.Del(c.ctx, id)
+	_ = c.store.Del(c.ctx, id)
+	c.untagKey(id)
+	c.publishInvalidation(invalidationOpPurge, id, nil)
 }
 
-// Stats returns statistics for the in-memory cache.
+// Close stops the invalidation-bus subscription started by
+// EnableInvalidationBus, if any. It is safe to call even when the bus was
+// never enabled.
+func (c *Cache) Close() error {
+	if c.pubsubCancel != nil {
+		c.pubsubCancel()
+	}
+	return nil
+}
+
+// lenKeyer is implemented by stores that can report their size and held
+// keys, such as store/lru.Store or a store.MultiStore fronted by one.
+type lenKeyer interface {
+	Len() int
+	Keys() []interface{}
+}
+
+// Stats returns an atomically-read snapshot of the cache's event counters,
+// plus the fastest cache layer's size if it supports reporting one (e.g. an
+// in-memory LRU layer).
 func (c *Cache) Stats() Stats {
-	// Note: lru.Cache is not safe for concurrent access to its stats fields.
-	// In a real-world high-concurrency scenario, this might require locking.
-	return Stats{
-		MemLen:  c.memCache.Len(),
-		MemKeys: c.memCache.Keys(),
+	stats := Stats{
+		MemHits:           int(atomic.LoadInt64(&c.counters.memHits)),
+		MemMisses:         int(atomic.LoadInt64(&c.counters.memMisses)),
+		MemEvicts:         int(atomic.LoadInt64(&c.counters.memEvicts)),
+		RedisHits:         int(atomic.LoadInt64(&c.counters.redisHits)),
+		RedisMisses:       int(atomic.LoadInt64(&c.counters.redisMisses)),
+		RedisErrors:       int(atomic.LoadInt64(&c.counters.redisErrors)),
+		LoaderInvocations: int(atomic.LoadInt64(&c.counters.loaderInvocations)),
+	}
+	if lk, ok := c.store.(lenKeyer); ok {
+		stats.MemLen = lk.Len()
+		stats.MemKeys = lk.Keys()
 	}
+	return stats
 }
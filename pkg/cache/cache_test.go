@@ -1,11 +1,16 @@
 package cache
 
 import (
+	"context"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/go-redis/redismock/v9"
 	"github.com/stretchr/testify/require"
+
+	"github.com/Jeanga7/go-cache-demo/pkg/store"
 )
 
 func TestGetMiss(t *testing.T) {
@@ -72,7 +77,9 @@ func TestCacheFlow(t *testing.T) {
 	require.Equal(t, testData, val)
 
 	// 4. Expire memory, Get -> Hit from Redis
-	cache.memCache.Remove(testID)
+	ms, ok := cache.store.(*store.MultiStore)
+	require.True(t, ok)
+	require.NoError(t, ms.Stores()[0].Del(context.Background(), testID))
 	mock.ExpectGet(testID).SetVal(string(testData))
 	val, err = cache.Get(testID)
 	require.NoError(t, err)
@@ -103,3 +110,78 @@ func TestStats(t *testing.T) {
 	require.Equal(t, 2, stats.MemLen)
 	require.ElementsMatch(t, []interface{}{"key1", "key2"}, stats.MemKeys)
 }
+
+func TestGetOrLoad_SingleflightDedupesConcurrentMisses(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	opts := Options{RedisClient: db, MaxMemEntries: 10, DefaultTTL: time.Minute}
+	cache, err := New(opts)
+	require.NoError(t, err)
+
+	testID := "stampede:key"
+	testData := []byte("loaded data")
+
+	mock.ExpectGet(testID).RedisNil()
+	mock.ExpectGet(negativeKey(testID)).RedisNil()
+	mock.ExpectSet(testID, testData, time.Minute).SetVal("OK")
+
+	var loaderCalls int32
+	loader := func(ctx context.Context) ([]byte, time.Duration, error) {
+		atomic.AddInt32(&loaderCalls, 1)
+		time.Sleep(20 * time.Millisecond)
+		return testData, time.Minute, nil
+	}
+
+	const concurrency = 20
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	results := make([][]byte, concurrency)
+	errs := make([]error, concurrency)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			results[i], errs[i] = cache.GetOrLoad(context.Background(), testID, loader)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	for i := 0; i < concurrency; i++ {
+		require.NoError(t, errs[i])
+		require.Equal(t, testData, results[i])
+	}
+	require.EqualValues(t, 1, atomic.LoadInt32(&loaderCalls))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetOrLoad_NegativeCaching(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	opts := Options{RedisClient: db, MaxMemEntries: 10, DefaultTTL: time.Minute, NegativeTTL: time.Second}
+	cache, err := New(opts)
+	require.NoError(t, err)
+
+	testID := "missing:key"
+
+	var loaderCalls int32
+	loader := func(ctx context.Context) ([]byte, time.Duration, error) {
+		atomic.AddInt32(&loaderCalls, 1)
+		return nil, 0, ErrNotFound
+	}
+
+	mock.ExpectGet(testID).RedisNil()
+	mock.ExpectGet(negativeKey(testID)).RedisNil()
+	mock.ExpectSet(negativeKey(testID), negativeMarker, opts.NegativeTTL).SetVal("OK")
+	mock.ExpectGet(testID).RedisNil()
+
+	_, err = cache.GetOrLoad(context.Background(), testID, loader)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// Second call should hit the in-memory negative-cache marker and never
+	// reach the loader again.
+	_, err = cache.GetOrLoad(context.Background(), testID, loader)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.EqualValues(t, 1, atomic.LoadInt32(&loaderCalls))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
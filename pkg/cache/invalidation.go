@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/Jeanga7/go-cache-demo/pkg/store"
+)
+
+// UpdatePolicy controls how a Cache propagates its own Set/Purge calls to
+// other instances sharing the same invalidation bus.
+type UpdatePolicy int
+
+const (
+	// PolicyLocalOnly never publishes mutations. This is the default.
+	PolicyLocalOnly UpdatePolicy = iota
+	// PolicyBroadcastEvict publishes the key only; remote instances evict
+	// their local copy and re-fetch from the shared Redis layer on demand.
+	PolicyBroadcastEvict
+	// PolicyBroadcastUpdate also publishes the new value on Set, so remote
+	// instances can refresh their local copy without a Redis round trip.
+	// Purge still only publishes the key, since there is no value to carry.
+	PolicyBroadcastUpdate
+)
+
+const (
+	invalidationOpSet   = "set"
+	invalidationOpPurge = "purge"
+)
+
+// invalidationMessage is published on the invalidation bus after a Set or
+// Purge. InstanceID lets receivers ignore messages that originated from
+// themselves, avoiding self-evict loops.
+type invalidationMessage struct {
+	Op         string `json:"op"`
+	Key        string `json:"key"`
+	InstanceID string `json:"instanceID"`
+	Value      []byte `json:"value,omitempty"`
+}
+
+// newInstanceID returns a random identifier unique to this process's Cache.
+func newInstanceID() string {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// EnableInvalidationBus turns on cross-node cache invalidation over a Redis
+// pub/sub channel. After enabling, every Set/Purge on this Cache (subject to
+// UpdatePolicy) publishes a message on channel; this Cache also subscribes
+// to channel and evicts matching keys from its fastest (in-process) store
+// layer when another instance publishes, keeping per-node in-memory caches
+// from serving stale data after a write on a different node. It requires a
+// redis.UniversalClient, either from the default {lru, redis} store or
+// passed explicitly via Options.RedisClient alongside a custom Options.Store.
+func (c *Cache) EnableInvalidationBus(channel string) error {
+	if c.redisClient == nil {
+		return errors.New("cache: invalidation bus requires a redis client")
+	}
+
+	c.invalidationChannel = channel
+
+	sub := c.redisClient.Subscribe(c.ctx, channel)
+	subCtx, cancel := context.WithCancel(c.ctx)
+	c.pubsubCancel = cancel
+
+	go c.consumeInvalidations(subCtx, sub)
+	return nil
+}
+
+// publishInvalidation announces a local mutation on the invalidation bus, if
+// one is enabled and UpdatePolicy calls for it.
+func (c *Cache) publishInvalidation(op, key string, value []byte) {
+	if c.invalidationChannel == "" || c.updatePolicy == PolicyLocalOnly {
+		return
+	}
+
+	msg := invalidationMessage{Op: op, Key: key, InstanceID: c.instanceID}
+	if c.updatePolicy == PolicyBroadcastUpdate && op == invalidationOpSet {
+		msg.Value = value
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	c.redisClient.Publish(c.ctx, c.invalidationChannel, payload)
+}
+
+// consumeInvalidations reads messages from sub until ctx is cancelled.
+func (c *Cache) consumeInvalidations(ctx context.Context, sub *redis.PubSub) {
+	defer sub.Close()
+
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			c.handleInvalidationMessage(msg.Payload)
+		}
+	}
+}
+
+// handleInvalidationMessage applies a remote mutation to the fastest local
+// store layer, unless it originated from this same instance.
+func (c *Cache) handleInvalidationMessage(payload string) {
+	var msg invalidationMessage
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+		return
+	}
+	if msg.InstanceID == c.instanceID {
+		return
+	}
+
+	ms, ok := c.store.(*store.MultiStore)
+	if !ok || len(ms.Stores()) == 0 {
+		return
+	}
+	local := ms.Stores()[0]
+
+	if msg.Op == invalidationOpSet && len(msg.Value) > 0 {
+		_ = local.Set(c.ctx, msg.Key, msg.Value, c.defaultTTL)
+		return
+	}
+	_ = local.Del(c.ctx, msg.Key)
+}
@@ -0,0 +1,164 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jeanga7/go-cache-demo/pkg/store"
+)
+
+func TestInvalidationBus_BroadcastEvictPropagatesAcrossInstances(t *testing.T) {
+	mr := miniredis.RunT(t)
+	const channel = "cachecraft:invalidate:evict"
+
+	cacheA, err := New(Options{
+		RedisClient:   redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+		MaxMemEntries: 10, DefaultTTL: time.Minute,
+		UpdatePolicy: PolicyBroadcastEvict,
+	})
+	require.NoError(t, err)
+	defer cacheA.Close()
+	require.NoError(t, cacheA.EnableInvalidationBus(channel))
+
+	cacheB, err := New(Options{
+		RedisClient:   redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+		MaxMemEntries: 10, DefaultTTL: time.Minute,
+		UpdatePolicy: PolicyBroadcastEvict,
+	})
+	require.NoError(t, err)
+	defer cacheB.Close()
+	require.NoError(t, cacheB.EnableInvalidationBus(channel))
+
+	time.Sleep(50 * time.Millisecond) // let both subscriptions attach
+
+	testID := "shared:key"
+	cacheA.Set(testID, []byte("v1"))
+
+	val, err := cacheB.Get(testID) // backfills B's local layer with v1
+	require.NoError(t, err)
+	require.Equal(t, []byte("v1"), val)
+
+	cacheA.Set(testID, []byte("v2"))
+
+	require.Eventually(t, func() bool {
+		val, err := cacheB.Get(testID)
+		return err == nil && string(val) == "v2"
+	}, time.Second, 10*time.Millisecond, "B kept serving a stale local value after A's update")
+}
+
+func TestInvalidationBus_BroadcastUpdatePropagatesValueDirectly(t *testing.T) {
+	mr := miniredis.RunT(t)
+	const channel = "cachecraft:invalidate:update"
+
+	cacheA, err := New(Options{
+		RedisClient:   redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+		MaxMemEntries: 10, DefaultTTL: time.Minute,
+		UpdatePolicy: PolicyBroadcastUpdate,
+	})
+	require.NoError(t, err)
+	defer cacheA.Close()
+	require.NoError(t, cacheA.EnableInvalidationBus(channel))
+
+	cacheB, err := New(Options{
+		RedisClient:   redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+		MaxMemEntries: 10, DefaultTTL: time.Minute,
+		UpdatePolicy: PolicyBroadcastUpdate,
+	})
+	require.NoError(t, err)
+	defer cacheB.Close()
+	require.NoError(t, cacheB.EnableInvalidationBus(channel))
+
+	time.Sleep(50 * time.Millisecond)
+
+	testID := "shared:key"
+	cacheA.Set(testID, []byte("v1"))
+
+	ms, ok := cacheB.store.(*store.MultiStore)
+	require.True(t, ok)
+	local := ms.Stores()[0]
+
+	require.Eventually(t, func() bool {
+		val, err := local.Get(context.Background(), testID)
+		return err == nil && string(val) == "v1"
+	}, time.Second, 10*time.Millisecond, "B's local layer never received A's pushed value")
+}
+
+func TestInvalidationBus_GetOrLoadFillPropagatesAcrossInstances(t *testing.T) {
+	mr := miniredis.RunT(t)
+	const channel = "cachecraft:invalidate:getorload"
+
+	cacheA, err := New(Options{
+		RedisClient:   redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+		MaxMemEntries: 10, DefaultTTL: time.Minute,
+		UpdatePolicy: PolicyBroadcastEvict,
+	})
+	require.NoError(t, err)
+	defer cacheA.Close()
+	require.NoError(t, cacheA.EnableInvalidationBus(channel))
+
+	cacheB, err := New(Options{
+		RedisClient:   redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+		MaxMemEntries: 10, DefaultTTL: time.Minute,
+		UpdatePolicy: PolicyBroadcastEvict,
+	})
+	require.NoError(t, err)
+	defer cacheB.Close()
+	require.NoError(t, cacheB.EnableInvalidationBus(channel))
+
+	time.Sleep(50 * time.Millisecond) // let both subscriptions attach
+
+	testID := "shared:getorload"
+
+	// Seed only B's local layer with a stale value, bypassing Set so the
+	// shared Redis backing store stays empty and A's GetOrLoad below
+	// actually misses and invokes the loader, rather than finding B's
+	// value there first.
+	msB, ok := cacheB.store.(*store.MultiStore)
+	require.True(t, ok)
+	require.NoError(t, msB.Stores()[0].Set(context.Background(), testID, []byte("stale"), time.Minute))
+
+	loader := func(ctx context.Context) ([]byte, time.Duration, error) {
+		return []byte("fresh"), time.Minute, nil
+	}
+	val, err := cacheA.GetOrLoad(context.Background(), testID, loader)
+	require.NoError(t, err)
+	require.Equal(t, []byte("fresh"), val)
+
+	require.Eventually(t, func() bool {
+		val, err := cacheB.Get(testID)
+		return err == nil && string(val) == "fresh"
+	}, time.Second, 10*time.Millisecond, "B kept serving a stale local value after A's GetOrLoad fill")
+}
+
+func TestInvalidationBus_IgnoresOwnMessages(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	c, err := New(Options{
+		RedisClient:   redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+		MaxMemEntries: 10, DefaultTTL: time.Minute,
+		UpdatePolicy: PolicyBroadcastEvict,
+	})
+	require.NoError(t, err)
+	defer c.Close()
+	require.NoError(t, c.EnableInvalidationBus("cachecraft:invalidate:self"))
+
+	time.Sleep(50 * time.Millisecond)
+
+	testID := "self:key"
+	c.Set(testID, []byte("v1"))
+
+	// Give the bus time to deliver the message back to this same instance;
+	// it must be ignored rather than evicting our own fresh write.
+	time.Sleep(100 * time.Millisecond)
+
+	ms, ok := c.store.(*store.MultiStore)
+	require.True(t, ok)
+	val, err := ms.Stores()[0].Get(context.Background(), testID)
+	require.NoError(t, err)
+	require.Equal(t, []byte("v1"), val)
+}
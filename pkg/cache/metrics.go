@@ -0,0 +1,194 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/Jeanga7/go-cache-demo/pkg/store"
+)
+
+// EventType identifies what happened during an instrumented cache
+// operation, for Options.OnEvent subscribers.
+type EventType int
+
+const (
+	EventMemHit EventType = iota
+	EventMemMiss
+	EventMemEvict
+	EventRedisHit
+	EventRedisMiss
+	EventRedisError
+	EventLoaderInvoked
+)
+
+// String returns the event's Prometheus-style metric name, e.g. "mem_hit".
+func (t EventType) String() string {
+	switch t {
+	case EventMemHit:
+		return "mem_hit"
+	case EventMemMiss:
+		return "mem_miss"
+	case EventMemEvict:
+		return "mem_evict"
+	case EventRedisHit:
+		return "redis_hit"
+	case EventRedisMiss:
+		return "redis_miss"
+	case EventRedisError:
+		return "redis_error"
+	case EventLoaderInvoked:
+		return "loader_invoked"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is delivered to Options.OnEvent and Options.MetricsCollector after
+// each instrumented operation.
+type Event struct {
+	Type EventType
+	Key  string
+	// Latency is populated for EventLoaderInvoked.
+	Latency time.Duration
+}
+
+// MetricsCollector receives counts and latencies for cache events. See
+// cachemetrics/prometheus for a Prometheus-backed implementation.
+type MetricsCollector interface {
+	// IncCounter increments the counter for the named event (see
+	// EventType.String).
+	IncCounter(event string)
+	// ObserveLatency records a duration for the named event.
+	ObserveLatency(event string, d time.Duration)
+}
+
+// cacheCounters holds the atomically-updated counts backing Cache.Stats.
+type cacheCounters struct {
+	memHits           int64
+	memMisses         int64
+	memEvicts         int64
+	redisHits         int64
+	redisMisses       int64
+	redisErrors       int64
+	loaderInvocations int64
+}
+
+// recordEvent updates the atomic counters and forwards evt to MetricsCollector
+// and OnEvent, if configured.
+func (c *Cache) recordEvent(evt Event) {
+	switch evt.Type {
+	case EventMemHit:
+		atomic.AddInt64(&c.counters.memHits, 1)
+	case EventMemMiss:
+		atomic.AddInt64(&c.counters.memMisses, 1)
+	case EventMemEvict:
+		atomic.AddInt64(&c.counters.memEvicts, 1)
+	case EventRedisHit:
+		atomic.AddInt64(&c.counters.redisHits, 1)
+	case EventRedisMiss:
+		atomic.AddInt64(&c.counters.redisMisses, 1)
+	case EventRedisError:
+		atomic.AddInt64(&c.counters.redisErrors, 1)
+	case EventLoaderInvoked:
+		atomic.AddInt64(&c.counters.loaderInvocations, 1)
+	}
+
+	if c.metrics != nil {
+		c.metrics.IncCounter(evt.Type.String())
+		if evt.Type == EventLoaderInvoked {
+			c.metrics.ObserveLatency(evt.Type.String(), evt.Latency)
+		}
+	}
+	if c.onEvent != nil {
+		c.onEvent(evt)
+	}
+}
+
+// skipMetricsKey is the context.Value key used by skipMetricsContext.
+type skipMetricsKey struct{}
+
+// skipMetricsContext tags ctx so instrumentedStore.Get passes the call
+// through to the inner store without recording a hit/miss/error event. It is
+// used for internal store operations, such as GetOrLoad's negative-cache
+// marker lookups, that would otherwise be double-counted alongside the
+// caller-visible key they shadow.
+func skipMetricsContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipMetricsKey{}, true)
+}
+
+// instrumentedStore wraps a store.Store so that every Get reports a hit,
+// miss, or error event tagged with layer ("mem" or "redis") to its owning
+// Cache, unless ctx was tagged with skipMetricsContext.
+type instrumentedStore struct {
+	inner store.Store
+	c     *Cache
+	layer string
+}
+
+func (s *instrumentedStore) Get(ctx context.Context, key string) ([]byte, error) {
+	val, err := s.inner.Get(ctx, key)
+	if skip, _ := ctx.Value(skipMetricsKey{}).(bool); skip {
+		return val, err
+	}
+	switch {
+	case err == nil:
+		s.c.recordEvent(Event{Type: s.hitType(), Key: key})
+	case errors.Is(err, store.ErrNotFound):
+		s.c.recordEvent(Event{Type: s.missType(), Key: key})
+	default:
+		s.c.recordEvent(Event{Type: s.errorType(), Key: key})
+	}
+	return val, err
+}
+
+func (s *instrumentedStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.inner.Set(ctx, key, value, ttl)
+}
+
+func (s *instrumentedStore) Del(ctx context.Context, key string) error {
+	return s.inner.Del(ctx, key)
+}
+
+func (s *instrumentedStore) Name() string { return s.inner.Name() }
+
+// Len passes through to inner if it reports its size, so Cache.Stats keeps
+// working when the mem layer is wrapped for instrumentation.
+func (s *instrumentedStore) Len() int {
+	if lk, ok := s.inner.(interface{ Len() int }); ok {
+		return lk.Len()
+	}
+	return 0
+}
+
+// Keys passes through to inner if it reports its keys.
+func (s *instrumentedStore) Keys() []interface{} {
+	if lk, ok := s.inner.(interface{ Keys() []interface{} }); ok {
+		return lk.Keys()
+	}
+	return nil
+}
+
+func (s *instrumentedStore) hitType() EventType {
+	if s.layer == "mem" {
+		return EventMemHit
+	}
+	return EventRedisHit
+}
+
+func (s *instrumentedStore) missType() EventType {
+	if s.layer == "mem" {
+		return EventMemMiss
+	}
+	return EventRedisMiss
+}
+
+func (s *instrumentedStore) errorType() EventType {
+	if s.layer == "mem" {
+		// The in-memory store never returns a non-ErrNotFound error; fall
+		// back to a miss rather than inventing a mem-error event.
+		return EventMemMiss
+	}
+	return EventRedisError
+}
@@ -0,0 +1,145 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jeanga7/go-cache-demo/pkg/store/mock"
+)
+
+func TestStats_TracksMemAndRedisHitsAndMisses(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+	c, err := New(Options{RedisClient: client, MaxMemEntries: 10, DefaultTTL: time.Minute})
+	require.NoError(t, err)
+
+	_, err = c.Get("missing")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	c.Set("k", []byte("v"))
+
+	val, err := c.Get("k")
+	require.NoError(t, err)
+	require.Equal(t, []byte("v"), val)
+
+	stats := c.Stats()
+	require.Equal(t, 1, stats.MemMisses)
+	require.Equal(t, 1, stats.RedisMisses)
+	require.Equal(t, 1, stats.MemHits)
+	require.Equal(t, 0, stats.RedisHits)
+}
+
+func TestStats_GetOrLoadMissDoesNotDoubleCountNegativeCacheLookup(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+	c, err := New(Options{RedisClient: client, MaxMemEntries: 10, DefaultTTL: time.Minute})
+	require.NoError(t, err)
+
+	loader := func(ctx context.Context) ([]byte, time.Duration, error) {
+		return []byte("loaded"), time.Minute, nil
+	}
+
+	_, err = c.GetOrLoad(context.Background(), "k", loader)
+	require.NoError(t, err)
+
+	stats := c.Stats()
+	require.Equal(t, 1, stats.MemMisses, "the negative-cache probe must not be counted alongside the real key's miss")
+	require.Equal(t, 1, stats.RedisMisses, "the negative-cache probe must not be counted alongside the real key's miss")
+}
+
+func TestStats_TracksLoaderInvocations(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+	c, err := New(Options{RedisClient: client, MaxMemEntries: 10, DefaultTTL: time.Minute})
+	require.NoError(t, err)
+
+	loads := 0
+	loader := func(ctx context.Context) ([]byte, time.Duration, error) {
+		loads++
+		return []byte("loaded"), time.Minute, nil
+	}
+
+	val, err := c.GetOrLoad(context.Background(), "k", loader)
+	require.NoError(t, err)
+	require.Equal(t, []byte("loaded"), val)
+	require.Equal(t, 1, loads)
+	require.Equal(t, 1, c.Stats().LoaderInvocations)
+}
+
+func TestOnEvent_ReceivesMemEvictOnExpiry(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+	var evicted []string
+	c, err := New(Options{
+		RedisClient:   client,
+		MaxMemEntries: 10,
+		DefaultTTL:    time.Millisecond,
+		OnEvent: func(evt Event) {
+			if evt.Type == EventMemEvict {
+				evicted = append(evicted, evt.Key)
+			}
+		},
+	})
+	require.NoError(t, err)
+
+	c.Set("k", []byte("v"))
+	time.Sleep(5 * time.Millisecond)
+
+	_, _ = c.Get("k")
+	require.Contains(t, evicted, "k")
+}
+
+type fakeCollector struct {
+	counts map[string]int
+}
+
+func (f *fakeCollector) IncCounter(event string) {
+	if f.counts == nil {
+		f.counts = make(map[string]int)
+	}
+	f.counts[event]++
+}
+
+func (f *fakeCollector) ObserveLatency(event string, d time.Duration) {}
+
+func TestStats_InstrumentsCallerSuppliedStore(t *testing.T) {
+	c, err := New(Options{Store: mock.New(), DefaultTTL: time.Minute})
+	require.NoError(t, err)
+
+	_, err = c.Get("missing")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	c.Set("k", []byte("v"))
+
+	val, err := c.Get("k")
+	require.NoError(t, err)
+	require.Equal(t, []byte("v"), val)
+
+	stats := c.Stats()
+	require.Equal(t, 1, stats.MemMisses)
+	require.Equal(t, 1, stats.MemHits)
+}
+
+func TestMetricsCollector_ReceivesCounterIncrements(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+	collector := &fakeCollector{}
+	c, err := New(Options{RedisClient: client, MaxMemEntries: 10, DefaultTTL: time.Minute, MetricsCollector: collector})
+	require.NoError(t, err)
+
+	c.Set("k", []byte("v"))
+	_, err = c.Get("k")
+	require.NoError(t, err)
+
+	require.Equal(t, 1, collector.counts[EventMemHit.String()])
+}
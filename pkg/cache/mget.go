@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/Jeanga7/go-cache-demo/pkg/store"
+)
+
+// MGet retrieves every key in keys, checking the fastest store layer first
+// and, if a redis.UniversalClient is available, fetching whatever is still
+// missing with a single pipelined Redis round trip rather than one Get per
+// key. Values found in Redis are backfilled into the fastest layer. Keys
+// absent from every layer are simply omitted from the result. The fastest
+// layer is read through its usual instrumentedStore, and each pipelined
+// Redis command is recorded as its own hit/miss/error event, so Stats() and
+// MetricsCollector see the same counters as Get/GetOrLoad would.
+func (c *Cache) MGet(ctx context.Context, keys []string) (map[string][]byte, error) {
+	out := make(map[string][]byte, len(keys))
+
+	ms, ok := c.store.(*store.MultiStore)
+	if !ok || len(ms.Stores()) == 0 || c.redisClient == nil {
+		for _, k := range keys {
+			v, err := c.store.Get(ctx, k)
+			if err == nil {
+				out[k] = v
+			} else if !errors.Is(err, ErrNotFound) {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+
+	fast := ms.Stores()[0]
+	var misses []string
+	for _, k := range keys {
+		if v, err := fast.Get(ctx, k); err == nil {
+			out[k] = v
+		} else if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		} else {
+			misses = append(misses, k)
+		}
+	}
+	if len(misses) == 0 {
+		return out, nil
+	}
+
+	cmds, err := c.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, k := range misses {
+			pipe.Get(ctx, k)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("cache: mget pipeline: %w", err)
+	}
+
+	for i, cmd := range cmds {
+		key := misses[i]
+		val, err := cmd.(*redis.StringCmd).Bytes()
+		switch {
+		case err == nil:
+			c.recordEvent(Event{Type: EventRedisHit, Key: key})
+			out[key] = val
+			_ = fast.Set(ctx, key, val, c.defaultTTL)
+		case errors.Is(err, redis.Nil):
+			c.recordEvent(Event{Type: EventRedisMiss, Key: key})
+		default:
+			c.recordEvent(Event{Type: EventRedisError, Key: key})
+		}
+	}
+	return out, nil
+}
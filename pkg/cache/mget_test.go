@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMGet_ReturnsOnlyKeysFoundAcrossLayers(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+	c, err := New(Options{RedisClient: client, MaxMemEntries: 10, DefaultTTL: time.Minute})
+	require.NoError(t, err)
+
+	c.Set("a", []byte("va"))
+	c.Set("b", []byte("vb"))
+
+	got, err := c.MGet(context.Background(), []string{"a", "b", "missing"})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"a": []byte("va"), "b": []byte("vb")}, got)
+}
+
+func TestMGet_FindsRedisOnlyKeysAndBackfillsMem(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+	c, err := New(Options{RedisClient: client, MaxMemEntries: 10, DefaultTTL: time.Minute})
+	require.NoError(t, err)
+
+	// Write directly to Redis, bypassing the mem layer, so MGet must fall
+	// back to the pipelined Redis path for this key.
+	require.NoError(t, client.Set(context.Background(), "redis-only", "vr", time.Minute).Err())
+
+	got, err := c.MGet(context.Background(), []string{"redis-only"})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"redis-only": []byte("vr")}, got)
+
+	// MGet should have backfilled the mem layer, so a direct Get now hits it.
+	memHitsBefore := c.Stats().MemHits
+	val, err := c.Get("redis-only")
+	require.NoError(t, err)
+	require.Equal(t, []byte("vr"), val)
+	require.Equal(t, memHitsBefore+1, c.Stats().MemHits)
+}
+
+func TestMGet_RecordsRedisHitAndMissMetrics(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+	c, err := New(Options{RedisClient: client, MaxMemEntries: 10, DefaultTTL: time.Minute})
+	require.NoError(t, err)
+
+	// Write directly to Redis, bypassing the mem layer, so the pipelined
+	// Redis fallback path is exercised for "redis-only" and "missing".
+	require.NoError(t, client.Set(context.Background(), "redis-only", "vr", time.Minute).Err())
+
+	got, err := c.MGet(context.Background(), []string{"redis-only", "missing"})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"redis-only": []byte("vr")}, got)
+
+	stats := c.Stats()
+	require.Equal(t, 1, stats.RedisHits, "pipelined Redis hit for redis-only was not recorded")
+	require.Equal(t, 1, stats.RedisMisses, "pipelined Redis miss for missing was not recorded")
+}
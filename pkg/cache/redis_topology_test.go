@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRedisUniversalClient_PicksTopologyByOptions(t *testing.T) {
+	t.Run("single node by default", func(t *testing.T) {
+		client := newRedisUniversalClient(Options{RedisAddr: "localhost:6379"})
+		defer client.Close()
+		_, ok := client.(*redis.Client)
+		require.True(t, ok, "expected *redis.Client, got %T", client)
+	})
+
+	t.Run("sentinel failover when MasterName and SentinelAddrs are set", func(t *testing.T) {
+		client := newRedisUniversalClient(Options{
+			MasterName:    "mymaster",
+			SentinelAddrs: []string{"localhost:26379"},
+		})
+		defer client.Close()
+		_, ok := client.(*redis.Client)
+		require.True(t, ok, "failover client should still satisfy *redis.Client, got %T", client)
+	})
+
+	t.Run("cluster when ClusterAddrs is set", func(t *testing.T) {
+		client := newRedisUniversalClient(Options{
+			ClusterAddrs: []string{"localhost:7000", "localhost:7001"},
+		})
+		defer client.Close()
+		_, ok := client.(*redis.ClusterClient)
+		require.True(t, ok, "expected *redis.ClusterClient, got %T", client)
+	})
+
+	t.Run("cluster takes precedence over sentinel", func(t *testing.T) {
+		client := newRedisUniversalClient(Options{
+			ClusterAddrs:  []string{"localhost:7000"},
+			MasterName:    "mymaster",
+			SentinelAddrs: []string{"localhost:26379"},
+		})
+		defer client.Close()
+		_, ok := client.(*redis.ClusterClient)
+		require.True(t, ok, "expected *redis.ClusterClient, got %T", client)
+	})
+}
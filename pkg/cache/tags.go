@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"github.com/redis/go-redis/v9"
+)
+
+// setOptions holds the options accumulated from a Set call's SetOption args.
+type setOptions struct {
+	tags []string
+}
+
+// SetOption customizes a Set call.
+type SetOption func(*setOptions)
+
+// WithTags associates a Set'd key with one or more tags, so it can later be
+// purged in bulk with InvalidateTags.
+func WithTags(tags ...string) SetOption {
+	return func(o *setOptions) {
+		o.tags = append(o.tags, tags...)
+	}
+}
+
+// tagSetKey returns the Redis key of the set tracking which cache keys carry
+// tag.
+func tagSetKey(tag string) string {
+	return "tag:" + tag
+}
+
+// indexTags records that id carries tags, in the local tag index (and its
+// keyTags reverse index) and, if a Redis client is available, in a
+// "tag:{name}" Redis set per tag. See Set's doc comment for why this isn't
+// atomic with the value write it follows.
+//
+// tagIndex and keyTags are both in-process and unbounded for the life of
+// the Cache: a key that is never explicitly Purged or InvalidateTags'd, but
+// instead left to expire via TTL, is never removed from either map, and
+// both are empty again after a process restart even though Redis's
+// "tag:{name}" sets persist. So untagKey/InvalidateTags can only clean up
+// Redis-side tag membership this instance actually indexed in its current
+// process lifetime — not tags assigned by another instance sharing the
+// same Redis, nor its own tags from before a restart, nor keys that simply
+// expired. Those cases leave a stale member in the relevant "tag:{name}"
+// Redis set until that tag is itself explicitly invalidated. Known
+// limitation.
+func (c *Cache) indexTags(id string, tags []string) {
+	c.tagMu.Lock()
+	for _, tag := range tags {
+		keys, ok := c.tagIndex[tag]
+		if !ok {
+			keys = make(map[string]struct{})
+			c.tagIndex[tag] = keys
+		}
+		keys[id] = struct{}{}
+	}
+	existing := make(map[string]struct{}, len(c.keyTags[id]))
+	for _, tag := range c.keyTags[id] {
+		existing[tag] = struct{}{}
+	}
+	for _, tag := range tags {
+		if _, ok := existing[tag]; !ok {
+			c.keyTags[id] = append(c.keyTags[id], tag)
+			existing[tag] = struct{}{}
+		}
+	}
+	c.tagMu.Unlock()
+
+	if c.redisClient == nil {
+		return
+	}
+	_, _ = c.redisClient.TxPipelined(c.ctx, func(pipe redis.Pipeliner) error {
+		for _, tag := range tags {
+			pipe.SAdd(c.ctx, tagSetKey(tag), id)
+		}
+		return nil
+	})
+}
+
+// untagKey removes id from every tag this instance locally knows it was
+// indexed under, in both tagIndex and, if a Redis client is available, the
+// "tag:{name}" Redis sets. Without this, a key that is purged or expires by
+// TTL (rather than going through InvalidateTags for every tag it carries)
+// would leave a stale reference behind in its other tags' index entries.
+// See indexTags's doc comment for the multi-instance limitation this is
+// still subject to.
+func (c *Cache) untagKey(id string) {
+	c.tagMu.Lock()
+	tags := c.keyTags[id]
+	delete(c.keyTags, id)
+	for _, tag := range tags {
+		delete(c.tagIndex[tag], id)
+		if len(c.tagIndex[tag]) == 0 {
+			delete(c.tagIndex, tag)
+		}
+	}
+	c.tagMu.Unlock()
+
+	if c.redisClient == nil || len(tags) == 0 {
+		return
+	}
+	_, _ = c.redisClient.Pipelined(c.ctx, func(pipe redis.Pipeliner) error {
+		for _, tag := range tags {
+			pipe.SRem(c.ctx, tagSetKey(tag), id)
+		}
+		return nil
+	})
+}
+
+// InvalidateTags purges every key associated with any of tags from every
+// cache layer, along with the tags' own index entries and, for each purged
+// key, its local membership in any OTHER tags it also carried (otherwise
+// those other tags would keep a stale reference to a now-deleted key). All
+// of this is batched into the same pipeline as the key/tag-set deletions,
+// so invalidating a tag shared by many keys still costs one Redis round
+// trip rather than one per key.
+func (c *Cache) InvalidateTags(tags ...string) {
+	invalidated := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		invalidated[tag] = struct{}{}
+	}
+
+	keys := make(map[string]struct{})
+	otherTagSRems := make(map[string][]string) // other tag -> keys to remove from it
+
+	c.tagMu.Lock()
+	for _, tag := range tags {
+		for k := range c.tagIndex[tag] {
+			keys[k] = struct{}{}
+		}
+		delete(c.tagIndex, tag)
+	}
+	for k := range keys {
+		for _, tag := range c.keyTags[k] {
+			if _, done := invalidated[tag]; done {
+				continue
+			}
+			delete(c.tagIndex[tag], k)
+			if len(c.tagIndex[tag]) == 0 {
+				delete(c.tagIndex, tag)
+			}
+			otherTagSRems[tag] = append(otherTagSRems[tag], k)
+		}
+		delete(c.keyTags, k)
+	}
+	c.tagMu.Unlock()
+
+	if c.redisClient != nil {
+		for _, tag := range tags {
+			members, err := c.redisClient.SMembers(c.ctx, tagSetKey(tag)).Result()
+			if err == nil {
+				for _, k := range members {
+					keys[k] = struct{}{}
+				}
+			}
+		}
+
+		_, _ = c.redisClient.Pipelined(c.ctx, func(pipe redis.Pipeliner) error {
+			for k := range keys {
+				pipe.Del(c.ctx, k)
+			}
+			for _, tag := range tags {
+				pipe.Del(c.ctx, tagSetKey(tag))
+			}
+			for tag, tagKeys := range otherTagSRems {
+				for _, k := range tagKeys {
+					pipe.SRem(c.ctx, tagSetKey(tag), k)
+				}
+			}
+			return nil
+		})
+	}
+
+	for k := range keys {
+		_ = c.store.Del(c.ctx, k)
+		c.publishInvalidation(invalidationOpPurge, k, nil)
+	}
+}
@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvalidateTags_PurgesAllTaggedKeys(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+	c, err := New(Options{RedisClient: client, MaxMemEntries: 10, DefaultTTL: time.Minute})
+	require.NoError(t, err)
+
+	c.Set("user:42:profile", []byte("profile data"), WithTags("user:42", "org:7"))
+	c.Set("user:42:settings", []byte("settings data"), WithTags("user:42"))
+	c.Set("org:7:info", []byte("org data"), WithTags("org:7"))
+	c.Set("unrelated:key", []byte("unrelated data"))
+
+	c.InvalidateTags("user:42")
+
+	_, err = c.Get("user:42:profile")
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = c.Get("user:42:settings")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// org:7:info still carries org:7 but not user:42, so it should survive.
+	val, err := c.Get("org:7:info")
+	require.NoError(t, err)
+	require.Equal(t, []byte("org data"), val)
+
+	val, err = c.Get("unrelated:key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("unrelated data"), val)
+
+	// The invalidated tag's own Redis set should also have been cleaned up.
+	exists, err := client.Exists(context.Background(), tagSetKey("user:42")).Result()
+	require.NoError(t, err)
+	require.Zero(t, exists)
+}
+
+func TestInvalidateTags_MultipleKeysShareATag(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+	c, err := New(Options{RedisClient: client, MaxMemEntries: 10, DefaultTTL: time.Minute})
+	require.NoError(t, err)
+
+	c.Set("a", []byte("a"), WithTags("shared"))
+	c.Set("b", []byte("b"), WithTags("shared"))
+
+	c.InvalidateTags("shared")
+
+	_, err = c.Get("a")
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = c.Get("b")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestInvalidateTags_PurgedKeyIsDroppedFromItsOtherTags(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+	c, err := New(Options{RedisClient: client, MaxMemEntries: 10, DefaultTTL: time.Minute})
+	require.NoError(t, err)
+
+	c.Set("user:42:profile", []byte("profile data"), WithTags("user:42", "org:7"))
+
+	c.InvalidateTags("user:42")
+
+	// user:42:profile also carried org:7; invalidating user:42 should have
+	// dropped it from org:7's index too, not just user:42's, so a later
+	// InvalidateTags("org:7") finds nothing stale to purge.
+	c.tagMu.Lock()
+	_, stillIndexed := c.tagIndex["org:7"]["user:42:profile"]
+	c.tagMu.Unlock()
+	require.False(t, stillIndexed, "purged key left a stale reference in org:7's local tag index")
+
+	members, err := client.SMembers(context.Background(), tagSetKey("org:7")).Result()
+	require.NoError(t, err)
+	require.NotContains(t, members, "user:42:profile", "purged key left a stale reference in org:7's Redis tag set")
+}
+
+func TestPurge_RemovesKeyFromItsTagIndexes(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+	c, err := New(Options{RedisClient: client, MaxMemEntries: 10, DefaultTTL: time.Minute})
+	require.NoError(t, err)
+
+	c.Set("user:42:profile", []byte("profile data"), WithTags("user:42"))
+	c.Purge("user:42:profile")
+
+	c.tagMu.Lock()
+	_, stillIndexed := c.tagIndex["user:42"]["user:42:profile"]
+	c.tagMu.Unlock()
+	require.False(t, stillIndexed, "Purge left a stale reference in the local tag index")
+
+	members, err := client.SMembers(context.Background(), tagSetKey("user:42")).Result()
+	require.NoError(t, err)
+	require.NotContains(t, members, "user:42:profile", "Purge left a stale reference in the Redis tag set")
+}
+
+func TestInvalidateTags_MultipleKeysOtherTagsAllCleanedUpInOnePipeline(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+	c, err := New(Options{RedisClient: client, MaxMemEntries: 10, DefaultTTL: time.Minute})
+	require.NoError(t, err)
+
+	c.Set("user:1:profile", []byte("p1"), WithTags("user:1", "org:7"))
+	c.Set("user:2:profile", []byte("p2"), WithTags("user:2", "org:7"))
+
+	c.InvalidateTags("user:1", "user:2")
+
+	members, err := client.SMembers(context.Background(), tagSetKey("org:7")).Result()
+	require.NoError(t, err)
+	require.Empty(t, members, "both purged keys should have been dropped from org:7's Redis tag set")
+}
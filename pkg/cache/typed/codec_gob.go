@@ -0,0 +1,48 @@
+package typed
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+)
+
+// GobCodec encodes values with encoding/gob. It is dependency-free like
+// JSONCodec but produces a smaller, non-human-readable payload; it is best
+// suited to Go-to-Go caching rather than values read by other languages.
+type GobCodec struct{}
+
+// Marshal implements Codec. gob.Encode panics on a nil pointer, so a nil v
+// (or a nil pointer inside v) is encoded as an empty payload instead of
+// being passed to the encoder.
+func (GobCodec) Marshal(v interface{}) ([]byte, error) {
+	if isNilPointer(v) {
+		return []byte{}, nil
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal implements Codec. An empty payload, written by Marshal for a nil
+// pointer, sets v to its zero value rather than being handed to the decoder,
+// which would fail on empty input.
+func (GobCodec) Unmarshal(data []byte, v interface{}) error {
+	if len(data) == 0 {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+			rv.Elem().Set(reflect.Zero(rv.Elem().Type()))
+		}
+		return nil
+	}
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// isNilPointer reports whether v is a nil pointer. A nil interface (T itself
+// being an interface type with no value set) isn't handled here; gob.Encode
+// still errors on that, same as before this fix.
+func isNilPointer(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
@@ -0,0 +1,17 @@
+package typed
+
+import "encoding/json"
+
+// JSONCodec encodes values with encoding/json. It is the default choice:
+// human-readable, dependency-free, and works with any JSON-marshalable type.
+type JSONCodec struct{}
+
+// Marshal implements Codec.
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal implements Codec.
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
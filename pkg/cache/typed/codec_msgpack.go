@@ -0,0 +1,18 @@
+package typed
+
+import "github.com/vmihailenco/msgpack/v5"
+
+// MsgpackCodec encodes values with MessagePack, a compact binary format
+// that is a faster, smaller drop-in alternative to JSONCodec for
+// performance-sensitive paths.
+type MsgpackCodec struct{}
+
+// Marshal implements Codec.
+func (MsgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	return msgpack.Marshal(v)
+}
+
+// Unmarshal implements Codec.
+func (MsgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	return msgpack.Unmarshal(data, v)
+}
@@ -0,0 +1,126 @@
+// Package typed provides a generic façade over cache.Cache that marshals
+// and unmarshals values of a caller-chosen type, so callers work with Go
+// values directly instead of manually encoding bytes.
+package typed
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Jeanga7/go-cache-demo/pkg/cache"
+)
+
+// Codec (de)serializes values to and from the bytes stored in a
+// cache.Cache. See JSONCodec, MsgpackCodec, and GobCodec for built-in
+// implementations.
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// Cache is a generic façade over cache.Cache: Get/Set/GetOrLoad/MGet all
+// work in terms of T, encoding and decoding through codec under the hood.
+type Cache[T any] struct {
+	c     *cache.Cache
+	codec Codec
+}
+
+// New wraps c with typed methods that (de)serialize values of type T using
+// codec.
+func New[T any](c *cache.Cache, codec Codec) *Cache[T] {
+	return &Cache[T]{c: c, codec: codec}
+}
+
+// Get retrieves and decodes the value stored under key. If key is absent,
+// it returns T's zero value and cache.ErrNotFound.
+func (tc *Cache[T]) Get(key string) (T, error) {
+	var zero T
+
+	raw, err := tc.c.Get(key)
+	if err != nil {
+		return zero, err
+	}
+
+	var v T
+	if err := tc.codec.Unmarshal(raw, &v); err != nil {
+		return zero, fmt.Errorf("typed cache: decode %q: %w", key, err)
+	}
+	return v, nil
+}
+
+// Set encodes value and stores it under key in every cache layer. Pass
+// cache.WithTags to associate the key with tags for later bulk invalidation.
+func (tc *Cache[T]) Set(key string, value T, opts ...cache.SetOption) error {
+	raw, err := tc.codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("typed cache: encode %q: %w", key, err)
+	}
+	tc.c.Set(key, raw, opts...)
+	return nil
+}
+
+// Purge removes key from every configured cache layer.
+func (tc *Cache[T]) Purge(key string) {
+	tc.c.Purge(key)
+}
+
+// Loader fetches the value for a cache key on a miss, analogous to
+// cache.Loader but returning a typed value instead of raw bytes.
+type Loader[T any] func(ctx context.Context) (T, error)
+
+// GetOrLoad retrieves and decodes key, falling back to loader on a miss and
+// populating every cache layer with the encoded result. It is backed by
+// cache.Cache.GetOrLoad, so concurrent calls for the same key are still
+// coalesced with singleflight and a loader returning cache.ErrNotFound is
+// still negative-cached.
+func (tc *Cache[T]) GetOrLoad(ctx context.Context, key string, loader Loader[T]) (T, error) {
+	var zero T
+
+	raw, err := tc.c.GetOrLoad(ctx, key, func(ctx context.Context) ([]byte, time.Duration, error) {
+		v, err := loader(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+		raw, err := tc.codec.Marshal(v)
+		if err != nil {
+			return nil, 0, err
+		}
+		return raw, 0, nil
+	})
+	if err != nil {
+		return zero, err
+	}
+
+	var v T
+	if err := tc.codec.Unmarshal(raw, &v); err != nil {
+		return zero, fmt.Errorf("typed cache: decode %q: %w", key, err)
+	}
+	return v, nil
+}
+
+// MGet retrieves and decodes every key in keys, pipelining the Redis
+// fallback for whatever misses the fastest layer (see cache.Cache.MGet).
+// Keys absent from every layer are simply omitted from the result; a key
+// whose stored bytes fail to decode makes the whole call fail, since a
+// partial, silently-incomplete result would be worse than an explicit error.
+func (tc *Cache[T]) MGet(keys []string) (map[string]T, error) {
+	raw, err := tc.c.MGet(context.Background(), keys)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]T, len(raw))
+	for key, val := range raw {
+		var v T
+		if err := tc.codec.Unmarshal(val, &v); err != nil {
+			return nil, fmt.Errorf("typed cache: decode %q: %w", key, err)
+		}
+		out[key] = v
+	}
+	return out, nil
+}
+
+// ErrNotFound is cache.ErrNotFound, re-exported so callers of this package
+// don't need to import pkg/cache just to check errors.Is against it.
+var ErrNotFound = cache.ErrNotFound
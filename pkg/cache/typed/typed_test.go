@@ -0,0 +1,132 @@
+package typed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jeanga7/go-cache-demo/pkg/cache"
+)
+
+type user struct {
+	ID   int
+	Name string
+}
+
+func newTestCache(t *testing.T) *cache.Cache {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	c, err := cache.New(cache.Options{RedisClient: client, MaxMemEntries: 10, DefaultTTL: time.Minute})
+	require.NoError(t, err)
+	return c
+}
+
+func TestTypedCache_SetAndGet(t *testing.T) {
+	for _, codec := range []Codec{JSONCodec{}, GobCodec{}, MsgpackCodec{}} {
+		users := New[user](newTestCache(t), codec)
+
+		require.NoError(t, users.Set("42", user{ID: 42, Name: "ada"}))
+
+		got, err := users.Get("42")
+		require.NoError(t, err)
+		require.Equal(t, user{ID: 42, Name: "ada"}, got)
+	}
+}
+
+func TestTypedCache_GetMissReturnsZeroValueAndErrNotFound(t *testing.T) {
+	users := New[user](newTestCache(t), JSONCodec{})
+
+	got, err := users.Get("missing")
+	require.ErrorIs(t, err, ErrNotFound)
+	require.Equal(t, user{}, got)
+}
+
+func TestTypedCache_ZeroValueAndNilPointerRoundTrip(t *testing.T) {
+	ints := New[int](newTestCache(t), JSONCodec{})
+	require.NoError(t, ints.Set("zero", 0))
+	got, err := ints.Get("zero")
+	require.NoError(t, err)
+	require.Zero(t, got)
+
+	ptrs := New[*user](newTestCache(t), JSONCodec{})
+	require.NoError(t, ptrs.Set("nil", nil))
+	gotPtr, err := ptrs.Get("nil")
+	require.NoError(t, err)
+	require.Nil(t, gotPtr)
+}
+
+func TestTypedCache_GobCodecNilPointerRoundTrip(t *testing.T) {
+	ptrs := New[*user](newTestCache(t), GobCodec{})
+	require.NoError(t, ptrs.Set("nil", nil))
+	gotPtr, err := ptrs.Get("nil")
+	require.NoError(t, err)
+	require.Nil(t, gotPtr)
+}
+
+func TestTypedCache_GetDecodeErrorWrapsCodecError(t *testing.T) {
+	c := newTestCache(t)
+	c.Set("42", []byte("not valid json"))
+
+	users := New[user](c, JSONCodec{})
+	_, err := users.Get("42")
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrNotFound)
+}
+
+func TestTypedCache_GetOrLoad_PopulatesCacheOnMiss(t *testing.T) {
+	users := New[user](newTestCache(t), JSONCodec{})
+
+	loads := 0
+	loader := func(ctx context.Context) (user, error) {
+		loads++
+		return user{ID: 7, Name: "grace"}, nil
+	}
+
+	got, err := users.GetOrLoad(context.Background(), "7", loader)
+	require.NoError(t, err)
+	require.Equal(t, user{ID: 7, Name: "grace"}, got)
+
+	got, err = users.GetOrLoad(context.Background(), "7", loader)
+	require.NoError(t, err)
+	require.Equal(t, user{ID: 7, Name: "grace"}, got)
+	require.Equal(t, 1, loads, "second call should hit the cache, not the loader")
+}
+
+func TestTypedCache_GetOrLoad_PropagatesLoaderError(t *testing.T) {
+	users := New[user](newTestCache(t), JSONCodec{})
+
+	wantErr := errors.New("upstream unavailable")
+	_, err := users.GetOrLoad(context.Background(), "7", func(ctx context.Context) (user, error) {
+		return user{}, wantErr
+	})
+	require.ErrorIs(t, err, wantErr)
+}
+
+func TestTypedCache_MGet_ReturnsOnlyFoundKeys(t *testing.T) {
+	users := New[user](newTestCache(t), JSONCodec{})
+
+	require.NoError(t, users.Set("1", user{ID: 1, Name: "a"}))
+	require.NoError(t, users.Set("2", user{ID: 2, Name: "b"}))
+
+	got, err := users.MGet([]string{"1", "2", "3"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]user{
+		"1": {ID: 1, Name: "a"},
+		"2": {ID: 2, Name: "b"},
+	}, got)
+}
+
+func TestTypedCache_MGet_DecodeErrorFailsTheWholeCall(t *testing.T) {
+	c := newTestCache(t)
+	c.Set("bad", []byte("not valid json"))
+
+	users := New[user](c, JSONCodec{})
+	_, err := users.MGet([]string{"bad"})
+	require.Error(t, err)
+}
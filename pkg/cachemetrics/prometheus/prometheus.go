@@ -0,0 +1,62 @@
+// Package prometheus adapts cache.MetricsCollector to Prometheus counters
+// and histograms, for use as Options.MetricsCollector.
+package prometheus
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Options configures the metric names and constant labels Collector
+// registers with.
+type Options struct {
+	// Namespace and Subsystem prefix every registered metric name, following
+	// Prometheus naming conventions (e.g. "myapp_cachecraft_events_total").
+	Namespace string
+	Subsystem string
+	// ConstLabels are attached to every metric emitted by this Collector,
+	// e.g. {"instance": "api-1"}.
+	ConstLabels prometheus.Labels
+}
+
+// Collector is a cache.MetricsCollector backed by Prometheus metrics. It
+// tracks one counter vector (events by name) and one histogram vector
+// (event latencies by name), both labeled by event.
+type Collector struct {
+	counters   *prometheus.CounterVec
+	histograms *prometheus.HistogramVec
+}
+
+// New creates a Collector and registers its metrics with reg.
+func New(reg prometheus.Registerer, opts Options) *Collector {
+	counters := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   opts.Namespace,
+		Subsystem:   opts.Subsystem,
+		Name:        "events_total",
+		Help:        "Total CacheCraft cache events, labeled by event name.",
+		ConstLabels: opts.ConstLabels,
+	}, []string{"event"})
+
+	histograms := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace:   opts.Namespace,
+		Subsystem:   opts.Subsystem,
+		Name:        "latency_seconds",
+		Help:        "CacheCraft operation latency in seconds, labeled by event name.",
+		ConstLabels: opts.ConstLabels,
+	}, []string{"event"})
+
+	reg.MustRegister(counters, histograms)
+
+	return &Collector{counters: counters, histograms: histograms}
+}
+
+// IncCounter implements cache.MetricsCollector.
+func (c *Collector) IncCounter(event string) {
+	c.counters.WithLabelValues(event).Inc()
+}
+
+// ObserveLatency implements cache.MetricsCollector.
+func (c *Collector) ObserveLatency(event string, d time.Duration) {
+	c.histograms.WithLabelValues(event).Observe(d.Seconds())
+}
@@ -0,0 +1,25 @@
+package prometheus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector_IncCounterAndObserveLatency(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	c := New(reg, Options{Namespace: "test", Subsystem: "cache"})
+
+	c.IncCounter("mem_hit")
+	c.IncCounter("mem_hit")
+	c.ObserveLatency("loader_invoked", 10*time.Millisecond)
+
+	require.Equal(t, float64(2), testutil.ToFloat64(c.counters.WithLabelValues("mem_hit")))
+
+	count, err := testutil.GatherAndCount(reg, "test_cache_latency_seconds")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
@@ -0,0 +1,66 @@
+// Package bigcache provides a store.Store backed by an in-process
+// allegro/bigcache instance.
+package bigcache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/allegro/bigcache/v3"
+
+	"github.com/Jeanga7/go-cache-demo/pkg/store"
+)
+
+// Store is a store.Store backed by a bigcache.BigCache.
+type Store struct {
+	cache *bigcache.BigCache
+}
+
+// New wraps an existing *bigcache.BigCache as a Store.
+func New(cache *bigcache.BigCache) *Store {
+	return &Store{cache: cache}
+}
+
+// NewDefault creates a BigCache whose entries are evicted after eviction has
+// elapsed and wraps it as a Store. BigCache evicts in whole windows rather
+// than per key, so the ttl passed to Set is not enforced per entry; size
+// eviction windows via eviction instead.
+func NewDefault(eviction time.Duration) (*Store, error) {
+	cache, err := bigcache.New(context.Background(), bigcache.DefaultConfig(eviction))
+	if err != nil {
+		return nil, fmt.Errorf("bigcache store: %w", err)
+	}
+	return &Store{cache: cache}, nil
+}
+
+// Get returns the value for key, or store.ErrNotFound if it is absent or has
+// aged out of its eviction window.
+func (s *Store) Get(ctx context.Context, key string) ([]byte, error) {
+	val, err := s.cache.Get(key)
+	if errors.Is(err, bigcache.ErrEntryNotFound) {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("bigcache GET error: %w", err)
+	}
+	return val, nil
+}
+
+// Set stores value under key. See NewDefault for how BigCache treats ttl.
+func (s *Store) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.cache.Set(key, value)
+}
+
+// Del removes key, if present.
+func (s *Store) Del(ctx context.Context, key string) error {
+	err := s.cache.Delete(key)
+	if errors.Is(err, bigcache.ErrEntryNotFound) {
+		return nil
+	}
+	return err
+}
+
+// Name identifies this backend.
+func (s *Store) Name() string { return "bigcache" }
@@ -0,0 +1,87 @@
+// Package lru provides an in-memory store.Store backed by
+// hashicorp/golang-lru.
+package lru
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/Jeanga7/go-cache-demo/pkg/store"
+)
+
+// entry is a single LRU-held value plus its expiration.
+type entry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// Store is a store.Store backed by a size-bounded in-memory LRU cache.
+type Store struct {
+	cache   *lru.Cache
+	onEvict func(key string)
+}
+
+// Option customizes a Store built by New.
+type Option func(*Store)
+
+// WithEvictCallback registers fn to be called whenever Get finds and removes
+// an expired entry.
+func WithEvictCallback(fn func(key string)) Option {
+	return func(s *Store) { s.onEvict = fn }
+}
+
+// New creates an LRU-backed Store that holds at most size entries.
+func New(size int, opts ...Option) (*Store, error) {
+	c, err := lru.New(size)
+	if err != nil {
+		return nil, fmt.Errorf("lru store: %w", err)
+	}
+	s := &Store{cache: c}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// Get returns the value for key, or store.ErrNotFound if it is absent or has
+// expired.
+func (s *Store) Get(ctx context.Context, key string) ([]byte, error) {
+	raw, ok := s.cache.Get(key)
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	e := raw.(entry)
+	if time.Now().After(e.expiresAt) {
+		s.cache.Remove(key)
+		if s.onEvict != nil {
+			s.onEvict(key)
+		}
+		return nil, store.ErrNotFound
+	}
+	return e.value, nil
+}
+
+// Set stores value under key, evicting the least recently used entry if the
+// cache is at capacity.
+func (s *Store) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.cache.Add(key, entry{value: value, expiresAt: time.Now().Add(ttl)})
+	return nil
+}
+
+// Del removes key, if present.
+func (s *Store) Del(ctx context.Context, key string) error {
+	s.cache.Remove(key)
+	return nil
+}
+
+// Name identifies this backend.
+func (s *Store) Name() string { return "lru" }
+
+// Len reports the number of entries currently held.
+func (s *Store) Len() int { return s.cache.Len() }
+
+// Keys reports the keys currently held.
+func (s *Store) Keys() []interface{} { return s.cache.Keys() }
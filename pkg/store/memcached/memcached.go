@@ -0,0 +1,53 @@
+// Package memcached provides a store.Store backed by a
+// bradfitz/gomemcache client.
+package memcached
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+
+	"github.com/Jeanga7/go-cache-demo/pkg/store"
+)
+
+// Store is a store.Store backed by Memcached.
+type Store struct {
+	client *memcache.Client
+}
+
+// New wraps an existing Memcached client as a Store.
+func New(client *memcache.Client) *Store {
+	return &Store{client: client}
+}
+
+// Get returns the value for key, or store.ErrNotFound if it is absent.
+func (s *Store) Get(ctx context.Context, key string) ([]byte, error) {
+	item, err := s.client.Get(key)
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("memcached GET error: %w", err)
+	}
+	return item.Value, nil
+}
+
+// Set stores value under key with the given TTL.
+func (s *Store) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.client.Set(&memcache.Item{Key: key, Value: value, Expiration: int32(ttl.Seconds())})
+}
+
+// Del removes key, if present.
+func (s *Store) Del(ctx context.Context, key string) error {
+	err := s.client.Delete(key)
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return nil
+	}
+	return err
+}
+
+// Name identifies this backend.
+func (s *Store) Name() string { return "memcached" }
@@ -0,0 +1,73 @@
+// Package mock provides an in-memory store.Store for unit tests that need a
+// cheap, dependency-free backend without pulling in redismock or a real
+// driver.
+package mock
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Jeanga7/go-cache-demo/pkg/store"
+)
+
+type item struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// Store is a mutex-guarded, in-memory store.Store.
+type Store struct {
+	mu    sync.Mutex
+	items map[string]item
+}
+
+// New returns an empty Store.
+func New() *Store {
+	return &Store{items: make(map[string]item)}
+}
+
+// Get returns the value for key, or store.ErrNotFound if it is absent or has
+// expired.
+func (s *Store) Get(ctx context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	it, ok := s.items[key]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	if time.Now().After(it.expiresAt) {
+		delete(s.items, key)
+		return nil, store.ErrNotFound
+	}
+	return it.value, nil
+}
+
+// Set stores value under key with the given TTL.
+func (s *Store) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.items[key] = item{value: value, expiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+// Del removes key, if present.
+func (s *Store) Del(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.items, key)
+	return nil
+}
+
+// Name identifies this backend.
+func (s *Store) Name() string { return "mock" }
+
+// Len reports the number of live entries currently held.
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items)
+}
@@ -0,0 +1,31 @@
+// Package nop provides a no-op store.Store, useful as a placeholder layer
+// (e.g. to disable a tier of a store.MultiStore without changing call sites).
+package nop
+
+import (
+	"context"
+	"time"
+
+	"github.com/Jeanga7/go-cache-demo/pkg/store"
+)
+
+// Store is a store.Store where every Get misses and every Set/Del is
+// discarded.
+type Store struct{}
+
+// New returns a no-op Store.
+func New() Store { return Store{} }
+
+// Get always reports store.ErrNotFound.
+func (Store) Get(ctx context.Context, key string) ([]byte, error) { return nil, store.ErrNotFound }
+
+// Set discards value and always succeeds.
+func (Store) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return nil
+}
+
+// Del is a no-op that always succeeds.
+func (Store) Del(ctx context.Context, key string) error { return nil }
+
+// Name identifies this backend.
+func (Store) Name() string { return "nop" }
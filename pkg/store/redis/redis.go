@@ -0,0 +1,50 @@
+// Package redis provides a store.Store backed by a Redis client.
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/Jeanga7/go-cache-demo/pkg/store"
+)
+
+// Store is a store.Store backed by Redis. client is a redis.UniversalClient
+// so the same Store works unmodified against a single node, a Sentinel
+// failover group, or a Redis Cluster.
+type Store struct {
+	client redis.UniversalClient
+}
+
+// New wraps an existing Redis client as a Store.
+func New(client redis.UniversalClient) *Store {
+	return &Store{client: client}
+}
+
+// Get returns the value for key, or store.ErrNotFound if it is absent.
+func (s *Store) Get(ctx context.Context, key string) ([]byte, error) {
+	val, err := s.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis GET error: %w", err)
+	}
+	return val, nil
+}
+
+// Set stores value under key with the given TTL.
+func (s *Store) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.client.Set(ctx, key, value, ttl).Err()
+}
+
+// Del removes key, if present.
+func (s *Store) Del(ctx context.Context, key string) error {
+	return s.client.Del(ctx, key).Err()
+}
+
+// Name identifies this backend.
+func (s *Store) Name() string { return "redis" }
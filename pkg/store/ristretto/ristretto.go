@@ -0,0 +1,63 @@
+// Package ristretto provides a store.Store backed by an in-process
+// dgraph-io/ristretto cache.
+package ristretto
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/ristretto"
+
+	"github.com/Jeanga7/go-cache-demo/pkg/store"
+)
+
+// Store is a store.Store backed by a ristretto.Cache.
+type Store struct {
+	cache *ristretto.Cache
+}
+
+// New wraps an existing *ristretto.Cache as a Store.
+func New(cache *ristretto.Cache) *Store {
+	return &Store{cache: cache}
+}
+
+// NewDefault creates a ristretto.Cache sized to hold roughly maxCost bytes
+// of values and wraps it as a Store.
+func NewDefault(maxCost int64) (*Store, error) {
+	cache, err := ristretto.NewCache(&ristretto.Config{
+		NumCounters: maxCost * 10,
+		MaxCost:     maxCost,
+		BufferItems: 64,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ristretto store: %w", err)
+	}
+	return &Store{cache: cache}, nil
+}
+
+// Get returns the value for key, or store.ErrNotFound if it is absent.
+// Ristretto applies TTL and admission decisions asynchronously, so a recent
+// Set may not be visible to an immediately following Get.
+func (s *Store) Get(ctx context.Context, key string) ([]byte, error) {
+	val, ok := s.cache.Get(key)
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return val.([]byte), nil
+}
+
+// Set stores value under key with the given TTL, costed by its byte length.
+func (s *Store) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.cache.SetWithTTL(key, value, int64(len(value)), ttl)
+	return nil
+}
+
+// Del removes key, if present.
+func (s *Store) Del(ctx context.Context, key string) error {
+	s.cache.Del(key)
+	return nil
+}
+
+// Name identifies this backend.
+func (s *Store) Name() string { return "ristretto" }
@@ -0,0 +1,125 @@
+// Package store defines the pluggable cache backend abstraction used by
+// cache.Cache. A Store is a single layer (in-memory, Redis, Memcached, ...);
+// MultiStore composes several of them into the ordered, write-through chain
+// that Cache itself used to hardcode. Concrete backends live in subpackages
+// (lru, redis, ristretto, bigcache, memcached, nop, mock) so core code never
+// needs to import a specific driver.
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotFound is returned by a Store when a key is absent or has expired.
+var ErrNotFound = errors.New("item not found in store")
+
+// Store is a single cache backend.
+type Store interface {
+	// Get returns the value for key, or ErrNotFound if it is absent or expired.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set stores value under key with the given TTL.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Del removes key, if present.
+	Del(ctx context.Context, key string) error
+	// Name identifies the backend, e.g. for logging or metrics labels.
+	Name() string
+}
+
+// MultiStore chains an ordered slice of stores, typically from fastest to
+// slowest (e.g. an in-process LRU ahead of Redis). Get walks the chain and
+// backfills every faster store once a hit is found further down, using
+// backfillTTL rather than whatever TTL the hit was originally stored with.
+// Set and Del are write-through: every store in the chain is updated.
+type MultiStore struct {
+	backfillTTL time.Duration
+	stores      []Store
+}
+
+// NewMultiStore builds a MultiStore over stores, in lookup order. backfillTTL
+// is the TTL applied when a hit on a slower store is copied into the faster
+// stores ahead of it.
+func NewMultiStore(backfillTTL time.Duration, stores ...Store) *MultiStore {
+	return &MultiStore{backfillTTL: backfillTTL, stores: stores}
+}
+
+// Stores returns the underlying stores, in lookup order.
+func (m *MultiStore) Stores() []Store {
+	return m.stores
+}
+
+// Get returns the first hit found while walking the chain in order,
+// backfilling every store visited before the hit.
+func (m *MultiStore) Get(ctx context.Context, key string) ([]byte, error) {
+	for i, s := range m.stores {
+		val, err := s.Get(ctx, key)
+		if err == nil {
+			for _, faster := range m.stores[:i] {
+				_ = faster.Set(ctx, key, val, m.backfillTTL)
+			}
+			return val, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// Set writes value to every store in the chain.
+func (m *MultiStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	for _, s := range m.stores {
+		if err := s.Set(ctx, key, value, ttl); err != nil {
+			return fmt.Errorf("%s store: %w", s.Name(), err)
+		}
+	}
+	return nil
+}
+
+// Del removes key from every store in the chain.
+func (m *MultiStore) Del(ctx context.Context, key string) error {
+	for _, s := range m.stores {
+		if err := s.Del(ctx, key); err != nil {
+			return fmt.Errorf("%s store: %w", s.Name(), err)
+		}
+	}
+	return nil
+}
+
+// Name identifies a MultiStore by its member stores, e.g. "multi(lru,redis)".
+func (m *MultiStore) Name() string {
+	name := "multi("
+	for i, s := range m.stores {
+		if i > 0 {
+			name += ","
+		}
+		name += s.Name()
+	}
+	return name + ")"
+}
+
+// Len reports the size of the first store in the chain if it exposes one
+// (e.g. an in-process LRU layer), or 0 otherwise.
+func (m *MultiStore) Len() int {
+	if len(m.stores) == 0 {
+		return 0
+	}
+	if lk, ok := m.stores[0].(interface{ Len() int }); ok {
+		return lk.Len()
+	}
+	return 0
+}
+
+// Keys reports the keys held by the first store in the chain if it exposes
+// them, or nil otherwise.
+func (m *MultiStore) Keys() []interface{} {
+	if len(m.stores) == 0 {
+		return nil
+	}
+	if lk, ok := m.stores[0].(interface{ Keys() []interface{} }); ok {
+		return lk.Keys()
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Jeanga7/go-cache-demo/pkg/store"
+	"github.com/Jeanga7/go-cache-demo/pkg/store/mock"
+)
+
+func TestMultiStore_BackfillsFasterStoresOnHit(t *testing.T) {
+	fast := mock.New()
+	slow := mock.New()
+	ms := store.NewMultiStore(time.Minute, fast, slow)
+
+	require.NoError(t, slow.Set(context.Background(), "key", []byte("value"), time.Minute))
+
+	val, err := ms.Get(context.Background(), "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), val)
+
+	// The hit on slow should have been backfilled into fast.
+	fastVal, err := fast.Get(context.Background(), "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), fastVal)
+}
+
+func TestMultiStore_GetMissReturnsErrNotFound(t *testing.T) {
+	ms := store.NewMultiStore(time.Minute, mock.New(), mock.New())
+
+	_, err := ms.Get(context.Background(), "missing")
+	require.ErrorIs(t, err, store.ErrNotFound)
+}
+
+func TestMultiStore_SetAndDelAreWriteThrough(t *testing.T) {
+	a := mock.New()
+	b := mock.New()
+	ms := store.NewMultiStore(time.Minute, a, b)
+
+	require.NoError(t, ms.Set(context.Background(), "key", []byte("value"), time.Minute))
+	for _, s := range []*mock.Store{a, b} {
+		val, err := s.Get(context.Background(), "key")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), val)
+	}
+
+	require.NoError(t, ms.Del(context.Background(), "key"))
+	for _, s := range []*mock.Store{a, b} {
+		_, err := s.Get(context.Background(), "key")
+		require.ErrorIs(t, err, store.ErrNotFound)
+	}
+}